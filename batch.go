@@ -0,0 +1,110 @@
+package pgxhelper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+)
+
+// batchItem pairs a queued query with the destination, if any, that
+// SendBatch should scan its result into.
+type batchItem struct {
+	dest     any
+	isSelect bool
+}
+
+// Batch wraps pgx.Batch, pairing each queued query with the destination that
+// SendBatch should scan its result into using scany.
+type Batch struct {
+	scanAPI  *pgxscan.API
+	pgxBatch *pgx.Batch
+	items    []batchItem
+}
+
+// NewBatch creates and returns a new Batch bound to this DBHelper's scanAPI.
+func (d *DBHelper) NewBatch() *Batch {
+	return &Batch{
+		scanAPI:  d.scanAPI,
+		pgxBatch: &pgx.Batch{},
+	}
+}
+
+// Queue appends a query with no scanned result, such as an INSERT or UPDATE
+// whose result isn't needed.
+func (b *Batch) Queue(query string, args ...any) {
+	b.pgxBatch.Queue(query, args...)
+	b.items = append(b.items, batchItem{})
+}
+
+// QueueGet appends a query expected to return a single row, scanned into dest
+// when SendBatch processes the results.
+func (b *Batch) QueueGet(dest any, query string, args ...any) {
+	b.pgxBatch.Queue(query, args...)
+	b.items = append(b.items, batchItem{dest: dest})
+}
+
+// QueueSelect appends a query expected to return multiple rows, scanned into
+// destSlice when SendBatch processes the results.
+func (b *Batch) QueueSelect(destSlice any, query string, args ...any) {
+	b.pgxBatch.Queue(query, args...)
+	b.items = append(b.items, batchItem{dest: destSlice, isSelect: true})
+}
+
+// Len returns the number of queries queued in the batch.
+func (b *Batch) Len() int {
+	return b.pgxBatch.Len()
+}
+
+// SendBatch sends b to the database and scans each result into its
+// registered destination. It returns the first error encountered, after
+// draining the remaining results so the underlying connection is left in a
+// usable state.
+func (d *DBHelper) SendBatch(ctx context.Context, b *Batch) error {
+	return d.observe("batch", func() error {
+		results := d.Querier(ctx).SendBatch(ctx, b.pgxBatch)
+		defer results.Close()
+
+		var firstErr error
+
+		for _, item := range b.items {
+			if err := consumeBatchItem(d.scanAPI, results, item); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+
+		return firstErr
+	})
+}
+
+// consumeBatchItem runs the next queued result in results and, if item
+// expects rows, scans them into its destination via scanAPI.
+func consumeBatchItem(scanAPI *pgxscan.API, results pgx.BatchResults, item batchItem) error {
+	if item.dest == nil {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("batch exec failed: %w", err)
+		}
+
+		return nil
+	}
+
+	rows, err := results.Query()
+	if err != nil {
+		return fmt.Errorf("batch query failed: %w", err)
+	}
+
+	if item.isSelect {
+		if err := scanAPI.ScanAll(item.dest, rows); err != nil {
+			return fmt.Errorf("batch scan failed: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := scanAPI.ScanOne(item.dest, rows); err != nil {
+		return fmt.Errorf("batch scan failed: %w", err)
+	}
+
+	return nil
+}
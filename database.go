@@ -3,6 +3,7 @@ package pgxhelper
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/georgysavva/scany/v2/dbscan"
@@ -14,8 +15,31 @@ import (
 
 // DBHelper is a wrapper around pgxpool.Pool to simplify common database operations.
 type DBHelper struct {
-	pool    *pgxpool.Pool
+	poolMu sync.RWMutex
+	pool   *pgxpool.Pool
+
+	connConf  *pgxpool.Config
+	dial      dialFunc
+	ping      pingFunc
+	closePool poolCloserFunc
+
 	scanAPI *pgxscan.API
+
+	metrics     *metrics
+	metricsStop chan struct{}
+	metricsWG   sync.WaitGroup
+
+	strictSingleLevelTx bool
+
+	connectRetryAttempts int
+	connectRetryBackoff  time.Duration
+
+	keepaliveInterval    time.Duration
+	keepaliveMaxFailures int
+	keepaliveStop        chan struct{}
+	keepaliveWG          sync.WaitGroup
+
+	errCh chan error
 }
 
 // Option is a functional option for configuring a DBHelper.
@@ -28,10 +52,30 @@ func WithScanAPI(scanAPI *pgxscan.API) Option {
 	}
 }
 
+// WithNestedTransactions controls how WithinTransaction behaves when a
+// transaction already exists in the context. By default (enabled, which is
+// also the zero value) it opens a savepoint and runs fn inside it. Passing
+// false restores the pre-savepoint behavior of panicking on nested calls, for
+// callers that want to enforce strictly single-level transactions.
+func WithNestedTransactions(enabled bool) Option {
+	return func(h *DBHelper) {
+		h.strictSingleLevelTx = !enabled
+	}
+}
+
 // New creates and returns a new DBHelper.
 func New(opts ...Option) *DBHelper {
 	h := &DBHelper{
 		scanAPI: mustNewAPI(mustNewDBScanAPI(dbscan.WithAllowUnknownColumns(true))),
+		dial:    buildPool,
+		ping: func(ctx context.Context, pool *pgxpool.Pool) error {
+			return pool.Ping(ctx)
+		},
+		closePool: func(pool *pgxpool.Pool) {
+			pool.Close()
+		},
+		connectRetryAttempts: 1,
+		errCh:                make(chan error, errChanBuffer),
 	}
 
 	for _, opt := range opts {
@@ -42,33 +86,39 @@ func New(opts ...Option) *DBHelper {
 }
 
 // Connect establishes a connection to the database using the provided connection string and timeout.
-// It creates a new pgxpool.Pool and pings the database to ensure the connection is live.
+// It creates a new pgxpool.Pool and pings the database to ensure the connection is live. If
+// WithConnectRetry was used, transient failures are retried before giving up.
 func (d *DBHelper) Connect(connStr string, timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
 	conf, err := pgxpool.ParseConfig(connStr)
 	if err != nil {
 		return fmt.Errorf("parse database config failure: %w", err)
 	}
 
-	d.pool, err = pgxpool.NewWithConfig(ctx, conf)
+	d.connConf = conf
+
+	pool, err := d.connectWithRetry(timeout)
 	if err != nil {
-		return fmt.Errorf("database pool creation failure: %w", err)
+		return err
 	}
 
-	return d.Ping(ctx)
+	d.setPool(pool)
+	d.startMetricsSampler()
+	d.startKeepalive()
+
+	return nil
 }
 
 // Ping checks the connection to the database.
 func (d *DBHelper) Ping(ctx context.Context) error {
-	err := d.pool.Ping(ctx)
+	pool := d.getPool()
+
+	err := pool.Ping(ctx)
 	if err != nil {
 		return fmt.Errorf("database ping error: %w", err)
 	}
 
-	rows, err := d.pool.Query(ctx, "SELECT 1 AS result FROM pg_database WHERE datname = $1",
-		d.pool.Config().ConnConfig.Database)
+	rows, err := pool.Query(ctx, "SELECT 1 AS result FROM pg_database WHERE datname = $1",
+		pool.Config().ConnConfig.Database)
 	if err != nil {
 		return fmt.Errorf("database query failure: %w", err)
 	}
@@ -79,7 +129,9 @@ func (d *DBHelper) Ping(ctx context.Context) error {
 
 // Close closes all connections in the pool and prevents further use.
 func (d *DBHelper) Close() {
-	d.pool.Close()
+	d.stopKeepalive()
+	d.stopMetricsSampler()
+	d.getPool().Close()
 }
 
 // Querier returns the appropriate querier from the context.
@@ -90,22 +142,52 @@ func (d *DBHelper) Querier(ctx context.Context) Querier {
 		return tx
 	}
 
+	return d.getPool()
+}
+
+// getPool returns the current pool, safe for concurrent use with a pool
+// rebuild triggered by the keepalive goroutine.
+func (d *DBHelper) getPool() *pgxpool.Pool {
+	d.poolMu.RLock()
+	defer d.poolMu.RUnlock()
+
 	return d.pool
 }
 
+// setPool atomically replaces the current pool.
+func (d *DBHelper) setPool(pool *pgxpool.Pool) {
+	d.poolMu.Lock()
+	defer d.poolMu.Unlock()
+
+	d.pool = pool
+}
+
 // WithinTransaction runs the given function within a transactional context.
+// If the context already contains a transaction, it opens a savepoint on it
+// and runs fn there instead of starting a new top-level transaction, unless
+// WithNestedTransactions(false) was used to request the old strict behavior,
+// in which case it panics.
 func (d *DBHelper) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, opt ...pgx.TxOptions) error {
-	d.requireNoTransaction(ctx)
+	if tx := ctxGetTx(ctx); tx != nil {
+		d.requireNoTransaction(ctx)
+
+		return d.observe("transaction", func() error {
+			return pgx.BeginFunc(ctx, tx, func(savepoint pgx.Tx) error {
+				return fn(ctxWithTx(ctx, savepoint))
+			})
+		})
+	}
 
 	var txOpt pgx.TxOptions
 	if len(opt) > 0 {
 		txOpt = opt[0]
 	}
 
-	return pgx.BeginTxFunc(ctx, d.pool, txOpt, func(tx pgx.Tx) error {
-		return fn(ctxWithTx(ctx, tx))
+	return d.observe("transaction", func() error {
+		return pgx.BeginTxFunc(ctx, d.getPool(), txOpt, func(tx pgx.Tx) error {
+			return fn(ctxWithTx(ctx, tx))
+		})
 	})
-
 }
 
 // private
@@ -161,8 +243,13 @@ func ctxGetTx(ctx context.Context) pgx.Tx {
 	return nil
 }
 
-// requireNoTransaction panics if the given context contains a transaction.
+// requireNoTransaction panics if the given context contains a transaction and
+// nested transactions were disabled via WithNestedTransactions(false).
 func (d *DBHelper) requireNoTransaction(ctx context.Context) {
+	if !d.strictSingleLevelTx {
+		return
+	}
+
 	if tx := ctxGetTx(ctx); tx != nil {
 		// Assume this is a code design error, not an error value.
 		panic("context already contains an unexpected transaction")
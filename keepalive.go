@@ -0,0 +1,193 @@
+package pgxhelper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// errChanBuffer is the capacity of the channel returned by Errors. Background
+// failures beyond this capacity are dropped rather than blocking the
+// keepalive goroutine.
+const errChanBuffer = 16
+
+// dialFunc builds a pool from conf, pinging it to ensure it's live. It's a
+// field on DBHelper (defaulting to buildPool) so tests can stub out the
+// actual dialing and count attempts.
+type dialFunc func(conf *pgxpool.Config, timeout time.Duration) (*pgxpool.Pool, error)
+
+// pingFunc pings pool. It's a field on DBHelper (defaulting to pool.Ping) so
+// tests can force keepalive failures without a live database.
+type pingFunc func(ctx context.Context, pool *pgxpool.Pool) error
+
+// poolCloserFunc closes a pool that's being replaced. It's a field on
+// DBHelper (defaulting to pool.Close) so tests can observe a pool swap
+// without touching a real *pgxpool.Pool.
+type poolCloserFunc func(pool *pgxpool.Pool)
+
+// WithConnectRetry is a functional option that makes the initial Connect call
+// try up to attempts times in total (the first try included), sleeping
+// backoff between attempts, instead of returning on the first error.
+func WithConnectRetry(attempts int, backoff time.Duration) Option {
+	return func(h *DBHelper) {
+		h.connectRetryAttempts = attempts
+		h.connectRetryBackoff = backoff
+	}
+}
+
+// WithKeepalive is a functional option that starts a background goroutine on
+// Connect which pings the database every interval. On failure it retries
+// with exponential backoff and, after maxFailures consecutive failures,
+// rebuilds the pool from the connection config used by Connect.
+func WithKeepalive(interval time.Duration, maxFailures int) Option {
+	return func(h *DBHelper) {
+		h.keepaliveInterval = interval
+		h.keepaliveMaxFailures = maxFailures
+	}
+}
+
+// Errors returns a channel on which background keepalive and reconnect
+// failures are published. The channel holds up to errChanBuffer failures;
+// sends never block, so once it is full further failures are dropped rather
+// than stalling the keepalive goroutine.
+func (d *DBHelper) Errors() <-chan error {
+	return d.errCh
+}
+
+// connectWithRetry builds a pool from d.connConf, making up to
+// d.connectRetryAttempts attempts in total (at least one) with
+// d.connectRetryBackoff between attempts.
+func (d *DBHelper) connectWithRetry(timeout time.Duration) (*pgxpool.Pool, error) {
+	attempts := d.connectRetryAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		pool, err := d.dial(d.connConf, timeout)
+		if err == nil {
+			return pool, nil
+		}
+
+		lastErr = err
+
+		if attempt < attempts {
+			time.Sleep(d.connectRetryBackoff)
+		}
+	}
+
+	return nil, lastErr
+}
+
+// buildPool creates a pool from conf and pings it to ensure it's live.
+func buildPool(conf *pgxpool.Config, timeout time.Duration) (*pgxpool.Pool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	pool, err := pgxpool.NewWithConfig(ctx, conf)
+	if err != nil {
+		return nil, fmt.Errorf("database pool creation failure: %w", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("database ping error: %w", err)
+	}
+
+	return pool, nil
+}
+
+// startKeepalive launches the background ping/reconnect goroutine, if
+// WithKeepalive was used.
+func (d *DBHelper) startKeepalive() {
+	if d.keepaliveInterval <= 0 {
+		return
+	}
+
+	d.keepaliveStop = make(chan struct{})
+	d.keepaliveWG.Add(1)
+
+	go d.runKeepalive()
+}
+
+// stopKeepalive signals the background goroutine to stop and waits for it to
+// exit.
+func (d *DBHelper) stopKeepalive() {
+	if d.keepaliveInterval <= 0 || d.keepaliveStop == nil {
+		return
+	}
+
+	close(d.keepaliveStop)
+	d.keepaliveWG.Wait()
+}
+
+// runKeepalive pings the database every d.keepaliveInterval. On failure it
+// backs off exponentially and, after d.keepaliveMaxFailures consecutive
+// failures, rebuilds the pool from the stored config. Every failure is
+// published on Errors().
+func (d *DBHelper) runKeepalive() {
+	defer d.keepaliveWG.Done()
+
+	ticker := time.NewTicker(d.keepaliveInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	backoff := d.keepaliveInterval
+
+	for {
+		select {
+		case <-d.keepaliveStop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), d.keepaliveInterval)
+		err := d.ping(ctx, d.getPool())
+		cancel()
+
+		if err == nil {
+			failures = 0
+			backoff = d.keepaliveInterval
+
+			continue
+		}
+
+		failures++
+		d.publishError(fmt.Errorf("keepalive ping failure: %w", err))
+
+		if d.keepaliveMaxFailures > 0 && failures >= d.keepaliveMaxFailures {
+			if pool, err := d.dial(d.connConf, d.keepaliveInterval); err != nil {
+				d.publishError(fmt.Errorf("keepalive pool rebuild failure: %w", err))
+			} else {
+				old := d.getPool()
+				d.setPool(pool)
+				d.closePool(old)
+				failures = 0
+			}
+
+			backoff = d.keepaliveInterval
+
+			continue
+		}
+
+		select {
+		case <-d.keepaliveStop:
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// publishError sends err on errCh without blocking if no one is receiving.
+func (d *DBHelper) publishError(err error) {
+	select {
+	case d.errCh <- err:
+	default:
+	}
+}
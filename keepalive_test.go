@@ -0,0 +1,163 @@
+package pgxhelper
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestConnectWithRetryExhaustsAttempts(t *testing.T) {
+	wantErr := errors.New("connection refused")
+
+	var attempts int
+
+	d := &DBHelper{
+		connectRetryAttempts: 3,
+		connectRetryBackoff:  time.Millisecond,
+		dial: func(conf *pgxpool.Config, timeout time.Duration) (*pgxpool.Pool, error) {
+			attempts++
+			return nil, wantErr
+		},
+	}
+
+	_, err := d.connectWithRetry(time.Second)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err = %v, want %v", err, wantErr)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestConnectWithRetrySucceedsAfterTransientFailures(t *testing.T) {
+	wantPool := &pgxpool.Pool{}
+
+	var attempts int
+
+	d := &DBHelper{
+		connectRetryAttempts: 5,
+		connectRetryBackoff:  time.Millisecond,
+		dial: func(conf *pgxpool.Config, timeout time.Duration) (*pgxpool.Pool, error) {
+			attempts++
+			if attempts < 3 {
+				return nil, errors.New("transient failure")
+			}
+
+			return wantPool, nil
+		},
+	}
+
+	gotPool, err := d.connectWithRetry(time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPool != wantPool {
+		t.Fatalf("pool = %v, want %v", gotPool, wantPool)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestConnectWithRetryDefaultsToASingleAttempt(t *testing.T) {
+	wantErr := errors.New("connection refused")
+
+	var attempts int
+
+	d := &DBHelper{
+		dial: func(conf *pgxpool.Config, timeout time.Duration) (*pgxpool.Pool, error) {
+			attempts++
+			return nil, wantErr
+		},
+	}
+
+	_, err := d.connectWithRetry(time.Second)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err = %v, want %v", err, wantErr)
+	}
+
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1", attempts)
+	}
+}
+
+// TestRunKeepaliveRebuildsPoolAfterMaxFailures drives runKeepalive directly
+// with stubbed ping/dial/closePool functions, so the pool swap under
+// poolMu can be exercised without a live database.
+func TestRunKeepaliveRebuildsPoolAfterMaxFailures(t *testing.T) {
+	oldPool := &pgxpool.Pool{}
+	newPool := &pgxpool.Pool{}
+
+	var (
+		pingCalls int32
+		dialCalls int32
+	)
+
+	closedCh := make(chan *pgxpool.Pool, 1)
+
+	d := &DBHelper{
+		pool:                 oldPool,
+		keepaliveInterval:    5 * time.Millisecond,
+		keepaliveMaxFailures: 2,
+		errCh:                make(chan error, errChanBuffer),
+		ping: func(ctx context.Context, pool *pgxpool.Pool) error {
+			n := atomic.AddInt32(&pingCalls, 1)
+			if pool == oldPool && n <= 2 {
+				return errors.New("ping failed")
+			}
+
+			return nil
+		},
+		dial: func(conf *pgxpool.Config, timeout time.Duration) (*pgxpool.Pool, error) {
+			atomic.AddInt32(&dialCalls, 1)
+			return newPool, nil
+		},
+		closePool: func(pool *pgxpool.Pool) {
+			closedCh <- pool
+		},
+	}
+
+	d.keepaliveStop = make(chan struct{})
+	d.keepaliveWG.Add(1)
+
+	go d.runKeepalive()
+	defer func() {
+		close(d.keepaliveStop)
+		d.keepaliveWG.Wait()
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-d.Errors():
+			if err == nil {
+				t.Fatal("expected a non-nil keepalive failure")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a keepalive ping failure")
+		}
+	}
+
+	select {
+	case closed := <-closedCh:
+		if closed != oldPool {
+			t.Fatalf("closed pool = %v, want the old pool %v", closed, oldPool)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the old pool to be closed")
+	}
+
+	if d.getPool() != newPool {
+		t.Fatalf("pool = %v, want the rebuilt pool %v", d.getPool(), newPool)
+	}
+
+	if atomic.LoadInt32(&dialCalls) == 0 {
+		t.Fatal("expected dial to be called to rebuild the pool")
+	}
+}
@@ -0,0 +1,187 @@
+package pgxhelper
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// metricsSampleInterval is how often pool statistics gauges are refreshed by
+// the background sampler that Connect starts when WithMetrics is used.
+const metricsSampleInterval = 5 * time.Second
+
+// metrics holds the prometheus collectors registered by WithMetrics.
+type metrics struct {
+	queryDuration *prometheus.HistogramVec
+
+	acquireCount            prometheus.Gauge
+	acquireDuration         prometheus.Gauge
+	acquiredConns           prometheus.Gauge
+	canceledAcquireCount    prometheus.Gauge
+	constructingConns       prometheus.Gauge
+	emptyAcquireCount       prometheus.Gauge
+	idleConns               prometheus.Gauge
+	maxConns                prometheus.Gauge
+	maxLifetimeDestroyCount prometheus.Gauge
+	maxIdleDestroyCount     prometheus.Gauge
+	newConnsCount           prometheus.Gauge
+	totalConns              prometheus.Gauge
+}
+
+// newMetrics creates and registers the DBHelper collectors against registerer.
+func newMetrics(registerer prometheus.Registerer) *metrics {
+	factory := promauto.With(registerer)
+
+	m := &metrics{
+		queryDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "pgxhelper",
+			Name:      "query_duration_seconds",
+			Help:      "Duration of DBHelper query methods, labeled by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		acquireCount: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_acquire_count",
+			Help:      "Cumulative count of successful connection acquires from the pool.",
+		}),
+		acquireDuration: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_acquire_duration_seconds",
+			Help:      "Cumulative time spent waiting for successful connection acquires from the pool.",
+		}),
+		acquiredConns: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_acquired_conns",
+			Help:      "Number of currently acquired connections in the pool.",
+		}),
+		canceledAcquireCount: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_canceled_acquire_count",
+			Help:      "Cumulative count of acquires from the pool that were canceled by a context.",
+		}),
+		constructingConns: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_constructing_conns",
+			Help:      "Number of connections currently being constructed.",
+		}),
+		emptyAcquireCount: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_empty_acquire_count",
+			Help:      "Cumulative count of acquires from the pool that waited for a resource to be released or constructed because the pool was empty.",
+		}),
+		idleConns: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_idle_conns",
+			Help:      "Number of currently idle connections in the pool.",
+		}),
+		maxConns: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_max_conns",
+			Help:      "Maximum size of the pool.",
+		}),
+		maxLifetimeDestroyCount: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_max_lifetime_destroy_count",
+			Help:      "Cumulative count of connections destroyed because they exceeded MaxConnLifetime.",
+		}),
+		maxIdleDestroyCount: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_max_idle_destroy_count",
+			Help:      "Cumulative count of connections destroyed because they exceeded MaxConnIdleTime.",
+		}),
+		newConnsCount: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_new_conns_count",
+			Help:      "Cumulative count of new connections opened.",
+		}),
+		totalConns: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pgxhelper",
+			Name:      "pool_total_conns",
+			Help:      "Total number of connections currently in the pool.",
+		}),
+	}
+
+	return m
+}
+
+// WithMetrics is a functional option that instruments DBHelper with
+// prometheus metrics: histograms for query execution time, labeled by method
+// (get/select/exec/batch/transaction), and gauges mirroring pgxpool.Stat(). A
+// background goroutine started on Connect and stopped on Close periodically
+// samples the pool statistics.
+func WithMetrics(registerer prometheus.Registerer) Option {
+	return func(h *DBHelper) {
+		h.metrics = newMetrics(registerer)
+	}
+}
+
+// observe runs fn, recording its duration against the query_duration_seconds
+// histogram under method if metrics are enabled.
+func (d *DBHelper) observe(method string, fn func() error) error {
+	if d.metrics == nil {
+		return fn()
+	}
+
+	start := time.Now()
+	err := fn()
+	d.metrics.queryDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+
+	return err
+}
+
+// startMetricsSampler launches the background goroutine that periodically
+// copies d.pool.Stat() into the pool gauges, until stopped by Close.
+func (d *DBHelper) startMetricsSampler() {
+	if d.metrics == nil {
+		return
+	}
+
+	d.metricsStop = make(chan struct{})
+	d.metricsWG.Add(1)
+
+	go func() {
+		defer d.metricsWG.Done()
+
+		ticker := time.NewTicker(metricsSampleInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				d.sampleMetrics()
+			case <-d.metricsStop:
+				return
+			}
+		}
+	}()
+}
+
+// stopMetricsSampler signals the background sampler to stop and waits for it
+// to exit.
+func (d *DBHelper) stopMetricsSampler() {
+	if d.metrics == nil || d.metricsStop == nil {
+		return
+	}
+
+	close(d.metricsStop)
+	d.metricsWG.Wait()
+}
+
+// sampleMetrics copies the current pgxpool.Stat() into the pool gauges.
+func (d *DBHelper) sampleMetrics() {
+	stat := d.getPool().Stat()
+
+	d.metrics.acquireCount.Set(float64(stat.AcquireCount()))
+	d.metrics.acquireDuration.Set(stat.AcquireDuration().Seconds())
+	d.metrics.acquiredConns.Set(float64(stat.AcquiredConns()))
+	d.metrics.canceledAcquireCount.Set(float64(stat.CanceledAcquireCount()))
+	d.metrics.constructingConns.Set(float64(stat.ConstructingConns()))
+	d.metrics.emptyAcquireCount.Set(float64(stat.EmptyAcquireCount()))
+	d.metrics.idleConns.Set(float64(stat.IdleConns()))
+	d.metrics.maxConns.Set(float64(stat.MaxConns()))
+	d.metrics.maxLifetimeDestroyCount.Set(float64(stat.MaxLifetimeDestroyCount()))
+	d.metrics.maxIdleDestroyCount.Set(float64(stat.MaxIdleDestroyCount()))
+	d.metrics.newConnsCount.Set(float64(stat.NewConnsCount()))
+	d.metrics.totalConns.Set(float64(stat.TotalConns()))
+}
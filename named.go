@@ -0,0 +1,194 @@
+package pgxhelper
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// GetNamed is like Get, but binds args using sqlx-style ":name" placeholders
+// instead of positional arguments. arg must be a map[string]any or a struct
+// (or pointer to struct) with "db" tags matching the placeholder names.
+func (d *DBHelper) GetNamed(ctx context.Context, dest any, query string, arg any) error {
+	q, args, err := bindNamed(query, arg)
+	if err != nil {
+		return err
+	}
+
+	return d.Get(ctx, dest, q, args...)
+}
+
+// SelectNamed is like Select, but binds args using sqlx-style ":name"
+// placeholders instead of positional arguments. arg must be a
+// map[string]any or a struct (or pointer to struct) with "db" tags matching
+// the placeholder names.
+func (d *DBHelper) SelectNamed(ctx context.Context, dest any, query string, arg any) error {
+	q, args, err := bindNamed(query, arg)
+	if err != nil {
+		return err
+	}
+
+	return d.Select(ctx, dest, q, args...)
+}
+
+// ExecNamed is like Exec, but binds args using sqlx-style ":name" placeholders
+// instead of positional arguments. arg must be a map[string]any or a struct
+// (or pointer to struct) with "db" tags matching the placeholder names.
+func (d *DBHelper) ExecNamed(ctx context.Context, query string, arg any) (int64, error) {
+	q, args, err := bindNamed(query, arg)
+	if err != nil {
+		return 0, err
+	}
+
+	return d.Exec(ctx, q, args...)
+}
+
+// private
+
+// bindNamed rewrites the ":name" placeholders in query into pgx's positional
+// "$N" placeholders and returns the argument list in matching order. It skips
+// over string literals, line/block comments, and "::" type casts so they
+// aren't mistaken for named placeholders.
+func bindNamed(query string, arg any) (string, []any, error) {
+	values, err := namedValues(arg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		out   strings.Builder
+		args  []any
+		seen  = make(map[string]int, len(values))
+		runes = []rune(query)
+		n     = len(runes)
+	)
+
+	for i := 0; i < n; i++ {
+		c := runes[i]
+
+		switch {
+		case c == '\'':
+			out.WriteRune(c)
+			i++
+			for i < n {
+				out.WriteRune(runes[i])
+				if runes[i] == '\'' {
+					if i+1 < n && runes[i+1] == '\'' {
+						i++
+						out.WriteRune(runes[i])
+						i++
+						continue
+					}
+					break
+				}
+				i++
+			}
+
+		case c == '-' && i+1 < n && runes[i+1] == '-':
+			for i < n && runes[i] != '\n' {
+				out.WriteRune(runes[i])
+				i++
+			}
+			if i < n {
+				out.WriteRune(runes[i])
+			}
+
+		case c == '/' && i+1 < n && runes[i+1] == '*':
+			out.WriteString("/*")
+			i += 2
+			for i < n-1 && !(runes[i] == '*' && runes[i+1] == '/') {
+				out.WriteRune(runes[i])
+				i++
+			}
+			if i < n {
+				out.WriteString("*/")
+				i++
+			}
+
+		case c == ':' && i+1 < n && runes[i+1] == ':':
+			out.WriteString("::")
+			i++
+
+		case c == ':' && i+1 < n && isNameStart(runes[i+1]):
+			j := i + 1
+			for j < n && isNameChar(runes[j]) {
+				j++
+			}
+			name := string(runes[i+1 : j])
+
+			idx, ok := seen[name]
+			if !ok {
+				val, ok := values[name]
+				if !ok {
+					return "", nil, fmt.Errorf("named parameter %q not found in arguments", name)
+				}
+
+				args = append(args, val)
+				idx = len(args)
+				seen[name] = idx
+			}
+
+			fmt.Fprintf(&out, "$%d", idx)
+			i = j - 1
+
+		default:
+			out.WriteRune(c)
+		}
+	}
+
+	return out.String(), args, nil
+}
+
+// namedValues resolves arg into a map of placeholder name to value. arg may
+// be a map[string]any or a struct (or pointer to struct) whose fields are
+// matched by their "db" tag, mirroring the convention already used by scany.
+func namedValues(arg any) (map[string]any, error) {
+	if m, ok := arg.(map[string]any); ok {
+		return m, nil
+	}
+
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("named arg is a nil pointer")
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("named arg must be a map[string]any or a struct, got %T", arg)
+	}
+
+	t := v.Type()
+	values := make(map[string]any, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("db")
+		if !ok {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" || name == "" {
+			continue
+		}
+
+		values[name] = v.Field(i).Interface()
+	}
+
+	return values, nil
+}
+
+func isNameStart(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isNameChar(r rune) bool {
+	return isNameStart(r) || (r >= '0' && r <= '9')
+}
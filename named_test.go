@@ -0,0 +1,101 @@
+package pgxhelper
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBindNamed(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		arg       any
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:      "positional rewrite with repeated name",
+			query:     `SELECT * FROM users WHERE id = :id OR parent_id = :id`,
+			arg:       map[string]any{"id": "1"},
+			wantQuery: `SELECT * FROM users WHERE id = $1 OR parent_id = $1`,
+			wantArgs:  []any{"1"},
+		},
+		{
+			name:      "string literal containing a colon-word is left untouched",
+			query:     `SELECT * FROM users WHERE name = 'not:a:param' AND id = :id`,
+			arg:       map[string]any{"id": "1"},
+			wantQuery: `SELECT * FROM users WHERE name = 'not:a:param' AND id = $1`,
+			wantArgs:  []any{"1"},
+		},
+		{
+			name:      "string literal with an escaped quote containing a colon-word",
+			query:     `SELECT * FROM users WHERE name = 'it''s :not_a_param' AND id = :id`,
+			arg:       map[string]any{"id": "1"},
+			wantQuery: `SELECT * FROM users WHERE name = 'it''s :not_a_param' AND id = $1`,
+			wantArgs:  []any{"1"},
+		},
+		{
+			name: "line comment containing a colon-word is left untouched",
+			query: "SELECT * FROM users -- skip :not_a_param\n" +
+				"WHERE id = :id",
+			arg:       map[string]any{"id": "1"},
+			wantQuery: "SELECT * FROM users -- skip :not_a_param\nWHERE id = $1",
+			wantArgs:  []any{"1"},
+		},
+		{
+			name:      "block comment containing a colon-word is left untouched",
+			query:     `SELECT * FROM users /* skip :not_a_param */ WHERE id = :id`,
+			arg:       map[string]any{"id": "1"},
+			wantQuery: `SELECT * FROM users /* skip :not_a_param */ WHERE id = $1`,
+			wantArgs:  []any{"1"},
+		},
+		{
+			name:      "double colon type cast is left untouched",
+			query:     `SELECT * FROM users WHERE id = ANY(:ids::text[])`,
+			arg:       map[string]any{"ids": []string{"1", "2"}},
+			wantQuery: `SELECT * FROM users WHERE id = ANY($1::text[])`,
+			wantArgs:  []any{[]string{"1", "2"}},
+		},
+		{
+			name:  "struct arg resolved via db tags",
+			query: `INSERT INTO users (id, name, email) VALUES (:id, :name, :email)`,
+			arg: struct {
+				ID    string `db:"id"`
+				Name  string `db:"name"`
+				EMail string `db:"email"`
+			}{ID: "1", Name: "Alice", EMail: "alice@example.com"},
+			wantQuery: `INSERT INTO users (id, name, email) VALUES ($1, $2, $3)`,
+			wantArgs:  []any{"1", "Alice", "alice@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs, err := bindNamed(tt.query, tt.arg)
+			if err != nil {
+				t.Fatalf("bindNamed returned error: %v", err)
+			}
+
+			if gotQuery != tt.wantQuery {
+				t.Errorf("query = %q, want %q", gotQuery, tt.wantQuery)
+			}
+
+			if len(gotArgs) != len(tt.wantArgs) {
+				t.Fatalf("args = %#v, want %#v", gotArgs, tt.wantArgs)
+			}
+
+			for i := range gotArgs {
+				if fmt.Sprintf("%#v", gotArgs[i]) != fmt.Sprintf("%#v", tt.wantArgs[i]) {
+					t.Errorf("args[%d] = %#v, want %#v", i, gotArgs[i], tt.wantArgs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBindNamedMissingKey(t *testing.T) {
+	_, _, err := bindNamed(`SELECT * FROM users WHERE id = :id`, map[string]any{})
+	if err == nil {
+		t.Fatal("expected an error for a missing named parameter")
+	}
+}
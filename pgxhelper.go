@@ -8,21 +8,33 @@ import (
 
 // Get queries for a single row and scans it into dest.
 func (d *DBHelper) Get(ctx context.Context, dest any, query string, args ...any) error {
-	return d.scanAPI.Get(ctx, d.Querier(ctx), dest, query, args...)
+	return d.observe("get", func() error {
+		return d.scanAPI.Get(ctx, d.Querier(ctx), dest, query, args...)
+	})
 }
 
 // Select queries for multiple rows and scans them into a slice.
 func (d *DBHelper) Select(ctx context.Context, dest any, query string, args ...any) error {
-	return d.scanAPI.Select(ctx, d.Querier(ctx), dest, query, args...)
+	return d.observe("select", func() error {
+		return d.scanAPI.Select(ctx, d.Querier(ctx), dest, query, args...)
+	})
 }
 
 // Exec executes a query that doesn't return rows, such as INSERT, UPDATE, or DELETE.
 // It returns the number of rows affected.
 func (d *DBHelper) Exec(ctx context.Context, query string, args ...any) (int64, error) {
-	tag, err := d.Querier(ctx).Exec(ctx, query, args...)
-	if err != nil {
-		return 0, fmt.Errorf("exec failed: %w", err)
-	}
+	var rowsAffected int64
 
-	return tag.RowsAffected(), nil
+	err := d.observe("exec", func() error {
+		tag, err := d.Querier(ctx).Exec(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("exec failed: %w", err)
+		}
+
+		rowsAffected = tag.RowsAffected()
+
+		return nil
+	})
+
+	return rowsAffected, err
 }
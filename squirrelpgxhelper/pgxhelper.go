@@ -0,0 +1,62 @@
+// Package squirrelpgxhelper provides a pgxhelper wrapper that accepts
+// github.com/Masterminds/squirrel query builders instead of raw SQL strings.
+package squirrelpgxhelper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/theprogrammer67/pgxhelper"
+)
+
+// StatementBuilder is a squirrel statement builder preconfigured with
+// Postgres's dollar-sign placeholder format. Callers building queries for use
+// with this package should start from it instead of squirrel.StatementBuilder.
+var StatementBuilder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+// DBHelper is a wrapper around pgxhelper.DBHelper that adds sqlizer-aware
+// query methods built on top of squirrel.Sqlizer.
+type DBHelper struct {
+	*pgxhelper.DBHelper
+}
+
+// New creates and returns a new DBHelper.
+func New(opts ...pgxhelper.Option) *DBHelper {
+	return &DBHelper{
+		DBHelper: pgxhelper.New(opts...),
+	}
+}
+
+// GetSq builds the query from sqlizer, executes it as a single-row query, and
+// scans the result into dest.
+func (d *DBHelper) GetSq(ctx context.Context, dest any, sqlizer squirrel.Sqlizer) error {
+	query, args, err := sqlizer.ToSql()
+	if err != nil {
+		return fmt.Errorf("build query failure: %w", err)
+	}
+
+	return d.DBHelper.Get(ctx, dest, query, args...)
+}
+
+// SelectSq builds the query from sqlizer, executes it, and scans the
+// resulting rows into the dest slice.
+func (d *DBHelper) SelectSq(ctx context.Context, dest any, sqlizer squirrel.Sqlizer) error {
+	query, args, err := sqlizer.ToSql()
+	if err != nil {
+		return fmt.Errorf("build query failure: %w", err)
+	}
+
+	return d.DBHelper.Select(ctx, dest, query, args...)
+}
+
+// ExecSq builds the query from sqlizer and executes it, returning the number
+// of affected rows.
+func (d *DBHelper) ExecSq(ctx context.Context, sqlizer squirrel.Sqlizer) (int64, error) {
+	query, args, err := sqlizer.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("build query failure: %w", err)
+	}
+
+	return d.DBHelper.Exec(ctx, query, args...)
+}
@@ -0,0 +1,58 @@
+package pgxhelper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Rows is the row set returned by Query, for manual row-by-row iteration.
+// Callers are responsible for closing it, typically via defer rows.Close().
+type Rows = pgx.Rows
+
+// Query executes a query and returns the resulting rows for manual iteration.
+// Unlike Select, it does not buffer the result set into a slice, which makes
+// it suitable for large result sets. Callers must close the returned Rows.
+func (d *DBHelper) Query(ctx context.Context, query string, args ...any) (Rows, error) {
+	rows, err := d.Querier(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ForEach executes query and, for every resulting row, scans it into dest and
+// invokes fn with it. The same dest is reused for every row, so each scan
+// overwrites the previous row's values. It stops at the first error returned
+// by scanning, by fn, or by context cancellation between rows.
+func (d *DBHelper) ForEach(ctx context.Context, dest any, query string, args []any, fn func(dest any) error) error {
+	rows, err := d.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	rs := d.scanAPI.NewRowScanner(rows)
+
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := rs.Scan(dest); err != nil {
+			return fmt.Errorf("scan row failure: %w", err)
+		}
+
+		if err := fn(dest); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration failure: %w", err)
+	}
+
+	return nil
+}
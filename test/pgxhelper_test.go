@@ -4,15 +4,20 @@ import (
 	"context"
 	"embed"
 	"errors"
+	"runtime"
 	"testing"
 	"time"
 
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
 	"github.com/theprogrammer67/pgxhelper"
 	"github.com/theprogrammer67/pgxhelper/sqlsetpgxhelper"
+	"github.com/theprogrammer67/pgxhelper/squirrelpgxhelper"
 	"github.com/theprogrammer67/sqlset"
 )
 
@@ -133,6 +138,207 @@ func (s *DBHelperSuite) TestDBHelper() {
 		s.Require().NoError(err)
 		s.Equal("john@example.com", customer.EMail)
 	})
+
+	s.Run("should insert and select user with named parameters", func() {
+		r, err := db.ExecNamed(ctx,
+			`INSERT INTO users (id, name, email) VALUES (:id, :name, :email)`,
+			User{ID: "444", Name: "Jane", EMail: "jane@example.com"})
+		s.Require().NoError(err)
+		s.Equal(int64(1), r, "expected 1 row affected")
+
+		var customer Customer
+		err = db.GetNamed(ctx, &customer,
+			`SELECT * FROM users WHERE id = :id`,
+			map[string]any{"id": "444"})
+		s.Require().NoError(err)
+		s.Equal("Jane", customer.Name)
+
+		var customers []Customer
+		err = db.SelectNamed(ctx, &customers,
+			`SELECT * FROM users WHERE id = ANY(:ids::text[])`,
+			map[string]any{"ids": []string{"333", "444"}})
+		s.Require().NoError(err)
+		s.Equal(2, len(customers))
+
+		_, err = db.ExecNamed(ctx,
+			`INSERT INTO users (id, name, email) VALUES (:id, :name, :missing)`,
+			User{ID: "555", Name: "Unused"})
+		s.ErrorContains(err, "missing")
+	})
+
+	s.Run("should rollback only the savepoint on nested transaction failure", func() {
+		err := db.WithinTransaction(ctx, func(outerCtx context.Context) error {
+			_, err := db.Exec(outerCtx, `INSERT INTO users (id, name, email) VALUES ($1, $2, $3)`, "666", "Outer", "outer@example.com")
+			if err != nil {
+				return err
+			}
+
+			err = db.WithinTransaction(outerCtx, func(innerCtx context.Context) error {
+				_, err := db.Exec(innerCtx, `INSERT INTO users (id, name, email) VALUES ($1, $2, $3)`, "777", "Inner", "inner@example.com")
+				if err != nil {
+					return err
+				}
+
+				return errors.New("rollback the savepoint")
+			})
+			s.Require().Error(err)
+
+			return nil
+		})
+		s.Require().NoError(err)
+
+		var outer Customer
+		err = db.Get(ctx, &outer, `SELECT * FROM users WHERE id = $1`, "666")
+		s.Require().NoError(err, "outer insert should have been committed")
+
+		var inner Customer
+		err = db.Get(ctx, &inner, `SELECT * FROM users WHERE id = $1`, "777")
+		s.ErrorIs(err, pgx.ErrNoRows, "inner insert should have been rolled back to the savepoint")
+	})
+
+	s.Run("should rollback only the savepoint when the nested callback panics", func() {
+		var panicked any
+
+		err := db.WithinTransaction(ctx, func(outerCtx context.Context) error {
+			_, err := db.Exec(outerCtx, `INSERT INTO users (id, name, email) VALUES ($1, $2, $3)`, "999", "OuterPanic", "outerpanic@example.com")
+			if err != nil {
+				return err
+			}
+
+			func() {
+				defer func() {
+					panicked = recover()
+				}()
+
+				_ = db.WithinTransaction(outerCtx, func(innerCtx context.Context) error {
+					_, err := db.Exec(innerCtx, `INSERT INTO users (id, name, email) VALUES ($1, $2, $3)`, "1010", "InnerPanic", "innerpanic@example.com")
+					if err != nil {
+						return err
+					}
+
+					panic("boom")
+				})
+			}()
+
+			return nil
+		})
+		s.Require().NoError(err, "outer transaction should still commit despite the nested panic")
+		s.Equal("boom", panicked, "panic from the nested callback should propagate out of the savepoint")
+
+		var outer Customer
+		err = db.Get(ctx, &outer, `SELECT * FROM users WHERE id = $1`, "999")
+		s.Require().NoError(err, "outer insert should have been committed")
+
+		var inner Customer
+		err = db.Get(ctx, &inner, `SELECT * FROM users WHERE id = $1`, "1010")
+		s.ErrorIs(err, pgx.ErrNoRows, "inner insert should have been rolled back to the savepoint, not the outer transaction")
+	})
+
+	s.Run("should stream rows with ForEach", func() {
+		var seen []string
+
+		var row User
+		err := db.ForEach(ctx, &row,
+			`SELECT * FROM users WHERE id = ANY($1::text[]) ORDER BY id`,
+			[]any{[]string{"111", "222"}},
+			func(dest any) error {
+				u := dest.(*User)
+				seen = append(seen, u.ID)
+				return nil
+			})
+		s.Require().NoError(err)
+		s.Equal([]string{"111", "222"}, seen)
+	})
+
+	s.Run("should send a batch and scan each result", func() {
+		b := db.NewBatch()
+		b.Queue(`INSERT INTO users (id, name, email) VALUES ($1, $2, $3)`, "888", "Batch", "batch@example.com")
+
+		var inserted Customer
+		b.QueueGet(&inserted, `SELECT * FROM users WHERE id = $1`, "888")
+
+		var all []Customer
+		b.QueueSelect(&all, `SELECT * FROM users WHERE id = ANY($1::text[]) ORDER BY id`, []string{"111", "222", "888"})
+
+		err := db.SendBatch(ctx, b)
+		s.Require().NoError(err)
+		s.Equal("batch@example.com", inserted.EMail)
+		s.Equal(3, len(all))
+	})
+}
+
+func (s *DBHelperSuite) TestDBHelperWithMetrics() {
+	registry := prometheus.NewRegistry()
+
+	db := pgxhelper.New(pgxhelper.WithMetrics(registry))
+	err := db.Connect(s.connStr, connTimeout)
+	s.Require().NoError(err, "failed to connect to test database")
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	_, err = db.Exec(ctx, `SELECT 1`)
+	s.Require().NoError(err)
+
+	metricFamilies, err := registry.Gather()
+	s.Require().NoError(err)
+	s.NotEmpty(metricFamilies)
+
+	var found bool
+	for _, mf := range metricFamilies {
+		if mf.GetName() == "pgxhelper_query_duration_seconds" {
+			found = true
+		}
+	}
+	s.True(found, "expected pgxhelper_query_duration_seconds to be registered")
+}
+
+func (s *DBHelperSuite) TestDBHelperMetricsAndKeepaliveCleanShutdown() {
+	before := runtime.NumGoroutine()
+
+	db := pgxhelper.New(
+		pgxhelper.WithMetrics(prometheus.NewRegistry()),
+		pgxhelper.WithKeepalive(20*time.Millisecond, 3),
+	)
+	err := db.Connect(s.connStr, connTimeout)
+	s.Require().NoError(err, "failed to connect to test database")
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	_, err = db.Exec(ctx, `SELECT 1`)
+	s.Require().NoError(err)
+
+	// Let the keepalive goroutine ping a few times while the metrics sampler
+	// goroutine is also running, so a leak would show up as extra live
+	// goroutines below.
+	time.Sleep(50 * time.Millisecond)
+
+	db.Close()
+
+	s.Eventually(func() bool {
+		return runtime.NumGoroutine() <= before
+	}, time.Second, 10*time.Millisecond, "Close should stop the metrics sampler and keepalive goroutines, not leak them")
+}
+
+func (s *DBHelperSuite) TestDBHelperWithKeepalive() {
+	db := pgxhelper.New(pgxhelper.WithKeepalive(100*time.Millisecond, 3))
+	err := db.Connect(s.connStr, connTimeout)
+	s.Require().NoError(err, "failed to connect to test database")
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	_, err = db.Exec(ctx, `SELECT 1`)
+	s.Require().NoError(err)
+
+	select {
+	case err := <-db.Errors():
+		s.Fail("unexpected background error", err)
+	case <-time.After(300 * time.Millisecond):
+	}
 }
 
 type Customer struct {
@@ -207,3 +413,55 @@ func (s *DBHelperSuite) TestDBHelperWithSQLSet() {
 	})
 
 }
+
+func (s *DBHelperSuite) TestDBHelperWithSquirrel() {
+	db := squirrelpgxhelper.New()
+	err := db.Connect(s.connStr, connTimeout)
+	s.Require().NoError(err, "failed to connect to test database")
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	_, err = db.Exec(ctx, `
+		CREATE TABLE  IF NOT EXISTS squirrel_users (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			email TEXT NOT NULL UNIQUE
+		)`)
+	s.Require().NoError(err, "failed to create table squirrel_users")
+
+	s.Run("should insert and select users via squirrel", func() {
+		insert := squirrelpgxhelper.StatementBuilder.Insert("squirrel_users").
+			Columns("id", "name", "email").
+			Values("111", "Alice", "alice@example.com")
+
+		r, err := db.ExecSq(ctx, insert)
+		s.Require().NoError(err)
+		s.Equal(int64(1), r, "expected 1 row affected")
+
+		var user User
+		getQuery := squirrelpgxhelper.StatementBuilder.Select("*").
+			From("squirrel_users").
+			Where(squirrel.Eq{"id": "111"})
+
+		err = db.GetSq(ctx, &user, getQuery)
+		s.Require().NoError(err)
+		s.Equal("alice@example.com", user.EMail)
+
+		r, err = db.ExecSq(ctx, squirrelpgxhelper.StatementBuilder.Insert("squirrel_users").
+			Columns("id", "name", "email").
+			Values("222", "Bob", "bob@example.com"))
+		s.Require().NoError(err)
+		s.Equal(int64(1), r, "expected 1 row affected")
+
+		var users []User
+		selectQuery := squirrelpgxhelper.StatementBuilder.Select("*").
+			From("squirrel_users").
+			Where(squirrel.Eq{"id": []string{"111", "222"}})
+
+		err = db.SelectSq(ctx, &users, selectQuery)
+		s.Require().NoError(err)
+		s.Equal(2, len(users))
+	})
+}